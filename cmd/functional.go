@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/faultproxy"
+	"github.com/spf13/cobra"
+)
+
+// functionalCmd runs a scripted fault-injection scenario against evcc's
+// transports (MQTT, Modbus, HTTP) and asserts loadpoint invariants while
+// it runs, catching reconnect/backoff regressions unit tests cannot.
+var functionalCmd = &cobra.Command{
+	Use:   "functional [scenario.yaml]",
+	Short: "Run a fault-injection scenario against synthetic devices",
+	Args:  cobra.ExactArgs(1),
+	Run:   runFunctional,
+}
+
+func init() {
+	rootCmd.AddCommand(functionalCmd)
+}
+
+// syntheticMaxCurrent is the max current a functional run's synthetic
+// charger is ever commanded to, so MaxCurrentInvariant has a fixed bound
+// to check against.
+const syntheticMaxCurrent int64 = 16
+
+// maxConsecutiveTransportFailures bounds how many failed attempts in a row
+// the synthetic charger's backend heartbeat (one attempt a tick) or command
+// publish (two attempts a tick, MaxCurrent and Enable) may pile up before
+// SustainedFailureInvariant trips. A handful of failures tolerates
+// the fault windows scenarios legitimately arm; climbing past it means
+// the transport never recovered.
+const maxConsecutiveTransportFailures = 5
+
+func runFunctional(cmd *cobra.Command, args []string) {
+	scenario, err := faultproxy.LoadScenario(args[0])
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	// backend stands in for whatever HTTP endpoint a real charger's cloud
+	// API or local web UI would expose; the synthetic charger polls it
+	// through httpFaults so "5xx"/"slow-body"/"dns-failure" steps are
+	// exercised by a live request, not just armed and ignored.
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	// httpFaults wraps http.DefaultTransport; only the synthetic charger's
+	// HTTP client goes through it, so "http" scenario steps arm faults on
+	// the charger's Status polls.
+	httpFaults := faultproxy.NewHTTPTransport(http.DefaultTransport)
+	httpClient := &http.Client{Transport: httpFaults}
+
+	mqttFaults := faultproxy.NewMQTTFaults()
+
+	modbusClient := faultproxy.NewModbusClient(syntheticRegisters{})
+	charger := newSyntheticCharger(modbusClient, httpClient, backend.URL, mqttFaults)
+
+	apply := map[string]func(faultproxy.Step) error{
+		"http":   applyHTTPFault(httpFaults),
+		"mqtt":   applyMQTTFault(mqttFaults),
+		"modbus": applyModbusFault(modbusClient),
+	}
+
+	runner := faultproxy.NewRunner(scenario, apply)
+
+	var lastCurrent int64
+	var wantEnabled atomic.Bool
+	wantEnabled.Store(true)
+
+	runner.AddInvariant("max-current", faultproxy.MaxCurrentInvariant(syntheticMaxCurrent, &lastCurrent))
+	runner.AddInvariant("enabled-while-disabled", faultproxy.EnabledWhileDisabledInvariant(charger, func() bool {
+		return !wantEnabled.Load()
+	}))
+	runner.AddInvariant("http-backend-unreachable", faultproxy.SustainedFailureInvariant(
+		"http backend heartbeat", maxConsecutiveTransportFailures, &charger.httpFailures))
+	runner.AddInvariant("mqtt-command-publish-stuck", faultproxy.SustainedFailureInvariant(
+		"mqtt command publish", maxConsecutiveTransportFailures, &charger.mqttFailures))
+
+	// drive the synthetic charger the way a loadpoint would, independent
+	// of the scenario's own schedule, so modbus/http/mqtt faults armed by
+	// applyModbusFault/applyHTTPFault/applyMQTTFault actually get
+	// exercised by live calls while the runner checks invariants after
+	// each step.
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go driveSyntheticCharger(charger, syntheticMaxCurrent, &lastCurrent, &wantEnabled, stop)
+
+	if err := runner.Run(); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	log.INFO.Printf("scenario %s completed without invariant violations", scenario.Name)
+}
+
+// driveSyntheticCharger periodically commands charger the way a loadpoint
+// would, recording the last successfully commanded current in last so
+// the runner's MaxCurrentInvariant has something to check. A tick whose
+// command failed (e.g. the mqtt publish backing it hit a disconnected
+// broker) leaves
+// last unchanged rather than recording the attempted value.
+func driveSyntheticCharger(charger *syntheticCharger, max int64, last *int64, wantEnabled *atomic.Bool, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := charger.MaxCurrent(max); err == nil {
+				*last = max
+			}
+			_ = charger.Enable(wantEnabled.Load())
+			_, _ = charger.Status()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func applyHTTPFault(t *faultproxy.HTTPTransport) func(faultproxy.Step) error {
+	return func(step faultproxy.Step) error {
+		switch step.Fault {
+		case "5xx":
+			t.Arm(faultproxy.HTTPMode5xx, 0)
+		case "slow-body":
+			t.Arm(faultproxy.HTTPModeSlowBody, durationParam(step, "delay"))
+		case "dns-failure":
+			t.Arm(faultproxy.HTTPModeDNSFailure, 0)
+		case "restore":
+			t.Disarm()
+		}
+		return nil
+	}
+}
+
+func applyMQTTFault(f *faultproxy.MQTTFaults) func(faultproxy.Step) error {
+	return func(step faultproxy.Step) error {
+		switch step.Fault {
+		case "drop":
+			f.DropPublishes(floatParam(step, "rate", 1))
+		case "delay":
+			f.DelayPublishes(durationParam(step, "delay"))
+		case "duplicate":
+			f.DuplicatePublishes(true)
+		case "disconnect":
+			f.DisconnectBroker(time.Duration(step.Duration))
+		case "restore":
+			f.Reset()
+		}
+		return nil
+	}
+}
+
+func applyModbusFault(c *faultproxy.ModbusClient) func(faultproxy.Step) error {
+	return func(step faultproxy.Step) error {
+		switch step.Fault {
+		case "latency":
+			c.Arm(faultproxy.ModbusModeLatency, durationParam(step, "delay"))
+		case "timeout":
+			c.Arm(faultproxy.ModbusModeTimeout, 0)
+		case "crc-corrupt":
+			c.Arm(faultproxy.ModbusModeCRCCorrupt, 0)
+		case "restore":
+			c.Disarm()
+		}
+		return nil
+	}
+}
+
+func durationParam(step faultproxy.Step, key string) (d time.Duration) {
+	if v, ok := step.Params[key]; ok {
+		if s, ok := v.(string); ok {
+			d, _ = time.ParseDuration(s)
+		}
+	}
+	return d
+}
+
+func floatParam(step faultproxy.Step, key string, def float64) float64 {
+	if v, ok := step.Params[key]; ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+	}
+	return def
+}
+
+// syntheticRegisters is a fake modbus register file that always succeeds,
+// used to back the synthetic charger's ModbusClient so functional runs
+// don't need a real modbus-speaking device to exercise modbus faults.
+type syntheticRegisters struct{}
+
+func (syntheticRegisters) ReadHoldingRegisters(address, quantity uint16) ([]byte, error) {
+	return make([]byte, 2*int(quantity)), nil
+}
+
+func (syntheticRegisters) ReadInputRegisters(address, quantity uint16) ([]byte, error) {
+	return make([]byte, 2*int(quantity)), nil
+}
+
+// syntheticCharger is a minimal in-process api.Charger that drives every
+// transport a functional run can fault: Enable/MaxCurrent publish their
+// command over MQTT before touching modbus, and Status polls an HTTP
+// backend, so a scenario's http/mqtt/modbus steps are all exercised
+// against a real device implementation rather than only the
+// fault-injection layer in isolation. httpFailures/mqttFailures count
+// consecutive failures of each so SustainedFailureInvariant can tell a
+// transient, expected blip from a reconnect/backoff regression.
+type syntheticCharger struct {
+	modbus *faultproxy.ModbusClient
+
+	http       *http.Client
+	backendURL string
+
+	mqtt       paho.Client
+	mqttFaults *faultproxy.MQTTFaults
+
+	httpFailures atomic.Int32
+	mqttFailures atomic.Int32
+
+	mu      sync.Mutex
+	enabled bool
+	current int64
+}
+
+func newSyntheticCharger(modbus *faultproxy.ModbusClient, httpClient *http.Client, backendURL string, mqttFaults *faultproxy.MQTTFaults) *syntheticCharger {
+	return &syntheticCharger{
+		modbus:     modbus,
+		http:       httpClient,
+		backendURL: backendURL,
+		mqtt:       fakeMQTTClient{},
+		mqttFaults: mqttFaults,
+	}
+}
+
+// Status polls the backend over HTTP and then reads a modbus register,
+// mirroring a charger whose status depends on both a cloud API and the
+// local bus. A failure of either call is returned as an error.
+func (c *syntheticCharger) Status() (api.ChargeStatus, error) {
+	resp, err := c.http.Get(c.backendURL)
+	if err != nil {
+		c.httpFailures.Add(1)
+		return api.StatusNone, err
+	}
+	resp.Body.Close()
+	c.httpFailures.Store(0)
+
+	if _, err := c.modbus.ReadHoldingRegisters(0, 1); err != nil {
+		return api.StatusNone, err
+	}
+	return api.StatusB, nil
+}
+
+func (c *syntheticCharger) Enabled() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enabled, nil
+}
+
+func (c *syntheticCharger) Enable(enable bool) error {
+	if err := c.publishCommand("enable", enable); err != nil {
+		return err
+	}
+
+	if _, err := c.modbus.ReadHoldingRegisters(1, 1); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.enabled = enable
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *syntheticCharger) MaxCurrent(current int64) error {
+	if err := c.publishCommand("maxcurrent", current); err != nil {
+		return err
+	}
+
+	if _, err := c.modbus.ReadHoldingRegisters(2, 1); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.current = current
+	c.mu.Unlock()
+
+	return nil
+}
+
+// publishCommand mqtt-publishes a command before it is applied, the way
+// evcc's mqtt-backed charger driver does, so an mqtt "disconnect" fault
+// armed against this run stops a command from taking effect. "drop" and
+// "delay" faults are exercised by the same publish but report success, so
+// the command still takes effect under them.
+func (c *syntheticCharger) publishCommand(topic string, payload any) error {
+	token := c.mqttFaults.Publish(c.mqtt, "charger/"+topic, 0, false, payload)
+	token.Wait()
+
+	if err := token.Error(); err != nil {
+		c.mqttFailures.Add(1)
+		return err
+	}
+
+	c.mqttFailures.Store(0)
+	return nil
+}
+
+var _ api.Charger = (*syntheticCharger)(nil)
+
+// fakeMQTTClient is a minimal in-process paho.Client that never actually
+// talks to a broker, used so MQTTFaults.Publish exercises a real publish
+// call during a functional run without requiring a live broker.
+type fakeMQTTClient struct{}
+
+func (fakeMQTTClient) IsConnected() bool      { return true }
+func (fakeMQTTClient) IsConnectionOpen() bool { return true }
+func (fakeMQTTClient) Connect() paho.Token    { return &fakeMQTTToken{} }
+func (fakeMQTTClient) Disconnect(uint)        {}
+
+func (fakeMQTTClient) Publish(topic string, qos byte, retained bool, payload interface{}) paho.Token {
+	return &fakeMQTTToken{}
+}
+
+func (fakeMQTTClient) Subscribe(topic string, qos byte, callback paho.MessageHandler) paho.Token {
+	return &fakeMQTTToken{}
+}
+
+func (fakeMQTTClient) SubscribeMultiple(filters map[string]byte, callback paho.MessageHandler) paho.Token {
+	return &fakeMQTTToken{}
+}
+
+func (fakeMQTTClient) Unsubscribe(topics ...string) paho.Token             { return &fakeMQTTToken{} }
+func (fakeMQTTClient) AddRoute(topic string, callback paho.MessageHandler) {}
+func (fakeMQTTClient) OptionsReader() paho.ClientOptionsReader             { return paho.ClientOptionsReader{} }
+
+type fakeMQTTToken struct{}
+
+func (fakeMQTTToken) Wait() bool                     { return true }
+func (fakeMQTTToken) WaitTimeout(time.Duration) bool { return true }
+func (fakeMQTTToken) Done() <-chan struct{}          { ch := make(chan struct{}); close(ch); return ch }
+func (fakeMQTTToken) Error() error                   { return nil }
+
+var _ paho.Client = fakeMQTTClient{}
+var _ paho.Token = (*fakeMQTTToken)(nil)
@@ -19,6 +19,7 @@ import (
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/meter"
+	"github.com/evcc-io/evcc/plugin"
 	"github.com/evcc-io/evcc/provider/golang"
 	"github.com/evcc-io/evcc/provider/javascript"
 	"github.com/evcc-io/evcc/provider/mqtt"
@@ -30,6 +31,7 @@ import (
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/hclog"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/evcc-io/evcc/util/machine"
 	"github.com/evcc-io/evcc/util/modbus"
@@ -65,31 +67,141 @@ var conf = globalConfig{
 }
 
 type globalConfig struct {
-	URI          interface{} // TODO deprecated
-	Network      networkConfig
-	Log          string
-	SponsorToken string
-	Plant        string // telemetry plant id
-	Telemetry    bool
-	Metrics      bool
-	Profile      bool
-	Levels       map[string]string
-	Interval     time.Duration
-	Database     dbConfig
-	Mqtt         mqttConfig
-	ModbusProxy  []proxyConfig
-	Javascript   []javascriptConfig
-	Go           []goConfig
-	Influx       server.InfluxConfig
-	EEBus        map[string]interface{}
-	HEMS         config.Typed
-	Messaging    messagingConfig
-	Meters       []config.Named
-	Chargers     []config.Named
-	Vehicles     []config.Named
-	Tariffs      tariffConfig
-	Site         map[string]interface{}
-	Loadpoints   []map[string]interface{}
+	URI           interface{} // TODO deprecated
+	Network       networkConfig
+	Log           string
+	SponsorToken  string
+	Plant         string // telemetry plant id
+	Telemetry     bool
+	Metrics       bool
+	Profile       bool
+	Levels        map[string]string
+	Interval      time.Duration
+	Database      dbConfig
+	Mqtt          mqttConfig
+	ModbusProxy   []proxyConfig
+	Javascript    []javascriptConfig
+	Go            []goConfig
+	Influx        server.InfluxConfig
+	EEBus         map[string]interface{}
+	HEMS          config.Typed
+	Messaging     messagingConfig
+	Meters        []config.Named
+	Chargers      []config.Named
+	Vehicles      []config.Named
+	Tariffs       tariffConfig
+	Site          map[string]interface{}
+	Loadpoints    []map[string]interface{}
+	Plugins       []pluginConfig
+	Logging       loggingConfig
+	SnapshotToken string // bearer token required to call the /api/config snapshot/restore routes
+}
+
+// loggingConfig selects the structured logging output and per-subsystem
+// levels, superseding the flat Levels map for deployments that want
+// correlatable JSON output (file, Loki, InfluxDB tags) instead of
+// printf-style strings.
+type loggingConfig struct {
+	Format   string // "text" (default) or "json"
+	Sampling int    // emit every Nth repeated line at INFO or below; 0 disables sampling
+	Levels   map[string]string
+}
+
+// configureLogging selects the wire format, per-subsystem levels and
+// sampling rate subsequent hclog.Logger instances use.
+func configureLogging(conf loggingConfig) error {
+	switch conf.Format {
+	case "", "text":
+		hclog.Format = hclog.FormatText
+	case "json":
+		hclog.Format = hclog.FormatJSON
+	default:
+		return fmt.Errorf("invalid logging format: %s", conf.Format)
+	}
+
+	hclog.Levels = conf.Levels
+	hclog.SetSampling(conf.Sampling)
+
+	return nil
+}
+
+type pluginConfig struct {
+	Name string
+	Cmd  string
+	Args []string
+	Env  []string
+}
+
+// pluginByName returns the supervised plugin configuration registered
+// under name, if any.
+func pluginByName(name string) (plugin.Config, bool) {
+	for _, cc := range conf.Plugins {
+		if cc.Name == name {
+			return plugin.Config{Name: cc.Name, Cmd: cc.Cmd, Args: cc.Args, Env: cc.Env}, true
+		}
+	}
+	return plugin.Config{}, false
+}
+
+// pluginConfigFromOther resolves the `plugin:` reference of a `type: plugin`
+// device entry against the top-level `plugins:` list.
+func pluginConfigFromOther(other map[string]interface{}) (plugin.Config, error) {
+	name, _ := other["plugin"].(string)
+	if name == "" {
+		return plugin.Config{}, errors.New("plugin: missing plugin name")
+	}
+
+	cc, ok := pluginByName(name)
+	if !ok {
+		return plugin.Config{}, fmt.Errorf("plugin '%s' not configured", name)
+	}
+
+	return cc, nil
+}
+
+// newMeterOrPlugin creates a meter from a static factory, or dispenses it
+// from an out-of-process plugin if typ is "plugin".
+func newMeterOrPlugin(typ string, other map[string]interface{}) (api.Meter, error) {
+	if typ != "plugin" {
+		return meter.NewFromConfig(typ, other)
+	}
+
+	cc, err := pluginConfigFromOther(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin.NewMeter(cc)
+}
+
+// newChargerOrPlugin creates a charger from a static factory, or dispenses
+// it from an out-of-process plugin if typ is "plugin".
+func newChargerOrPlugin(typ string, other map[string]interface{}) (api.Charger, error) {
+	if typ != "plugin" {
+		return charger.NewFromConfig(typ, other)
+	}
+
+	cc, err := pluginConfigFromOther(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin.NewCharger(cc)
+}
+
+// newVehicleOrPlugin creates a vehicle from a static factory, or dispenses
+// it from an out-of-process plugin if typ is "plugin".
+func newVehicleOrPlugin(typ string, other map[string]interface{}) (api.Vehicle, error) {
+	if typ != "plugin" {
+		return vehicle.NewFromConfig(typ, other)
+	}
+
+	cc, err := pluginConfigFromOther(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin.NewVehicle(cc)
 }
 
 type mqttConfig struct {
@@ -197,10 +309,12 @@ func configureMeters(static []config.Named) error {
 			return fmt.Errorf("cannot create meter %d: missing name", i+1)
 		}
 
-		instance, err := meter.NewFromConfig(cc.Type, cc.Other)
+		devLog := hclog.New("meter", hclog.Fields{"device": cc.Name})
+
+		instance, err := newMeterOrPlugin(cc.Type, cc.Other)
 		if err != nil {
-			err = fmt.Errorf("cannot create meter '%s': %w", cc.Name, err)
-			return err
+			devLog.ERROR.Printf("creating meter failed: %v", err)
+			return fmt.Errorf("cannot create meter '%s': %w", cc.Name, err)
 		}
 
 		dev.Connect(instance)
@@ -234,8 +348,11 @@ func configureChargers(static []config.Named) error {
 		i := i
 
 		g.Go(func() error {
-			instance, err := charger.NewFromConfig(cc.Type, cc.Other)
+			devLog := hclog.New("charger", hclog.Fields{"device": cc.Name})
+
+			instance, err := newChargerOrPlugin(cc.Type, cc.Other)
 			if err != nil {
+				devLog.ERROR.Printf("creating charger failed: %v", err)
 				return fmt.Errorf("cannot create charger '%s': %w", cc.Name, err)
 			}
 
@@ -279,7 +396,9 @@ func configureVehicles(static []config.Named) error {
 		i := i
 
 		g.Go(func() error {
-			instance, err := vehicle.NewFromConfig(cc.Type, cc.Other)
+			devLog := hclog.New("vehicle", hclog.Fields{"device": cc.Name})
+
+			instance, err := newVehicleOrPlugin(cc.Type, cc.Other)
 			if err != nil {
 				var ce *util.ConfigError
 				if errors.As(err, &ce) {
@@ -287,7 +406,7 @@ func configureVehicles(static []config.Named) error {
 				}
 
 				// wrap non-config vehicle errors to prevent fatals
-				log.ERROR.Printf("creating vehicle %s failed: %v", cc.Name, err)
+				devLog.ERROR.Printf("creating vehicle failed: %v", err)
 				instance = wrapper.New(cc.Name, cc.Other, err)
 			}
 
@@ -322,8 +441,11 @@ func configureEnvironment(cmd *cobra.Command, conf globalConfig) (err error) {
 		request.LogHeaders = true
 	}
 
+	// setup structured logging format
+	err = configureLogging(conf.Logging)
+
 	// setup machine id
-	if conf.Plant != "" {
+	if err == nil && conf.Plant != "" {
 		err = machine.CustomID(conf.Plant)
 	}
 
@@ -367,9 +489,123 @@ func configureEnvironment(cmd *cobra.Command, conf globalConfig) (err error) {
 		err = config.Init(db.Instance)
 	}
 
+	// watch config file for changes and apply them without a restart
+	if err == nil && cfgFile != "" {
+		err = configureConfigAggregator(cfgFile)
+	}
+
 	return
 }
 
+// configureConfigAggregator watches file for changes and reconciles them
+// into the running instance via a config.Aggregator. Only the file
+// provider is wired up for now; the gorm Config table is already
+// edit-in-place via the API, and env vars/remote sources require a
+// restart until their config.Provider implementations land.
+func configureConfigAggregator(file string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdown.Register(cancel)
+
+	aggregator := config.NewAggregator(util.NewLogger("cfg-aggregator"), 2*time.Second, config.NewFileProvider(file))
+
+	// seed with the configuration already applied by configureMeters et al.
+	// at startup, so the file provider's initial read is a no-op diff
+	// instead of re-adding every device.
+	tariffs, _ := viper.AllSettings()["tariffs"].(map[string]any)
+
+	aggregator.Seed(config.Snapshot{
+		Meters:     conf.Meters,
+		Chargers:   conf.Chargers,
+		Vehicles:   conf.Vehicles,
+		Loadpoints: conf.Loadpoints,
+		Tariffs:    tariffs,
+	})
+
+	go func() {
+		if err := aggregator.Run(ctx, setupApplier{}); err != nil && ctx.Err() == nil {
+			log.ERROR.Printf("config aggregator stopped: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// setupApplier reconciles config.Aggregator diffs against the running
+// device registry.
+type setupApplier struct{}
+
+func (setupApplier) ApplyMeter(action config.Action, cur, next config.Named) error {
+	if action == config.ActionRemove {
+		return config.RemoveMeter(cur.Name)
+	}
+
+	instance, err := newMeterOrPlugin(next.Type, next.Other)
+	if err != nil {
+		return fmt.Errorf("cannot create meter '%s': %w", next.Name, err)
+	}
+
+	dev := config.NewStaticDevice[api.Meter](next)
+	dev.Connect(instance)
+
+	if action == config.ActionUpdate {
+		return config.UpdateMeter(cur.Name, dev)
+	}
+
+	return config.AddMeter(dev)
+}
+
+func (setupApplier) ApplyCharger(action config.Action, cur, next config.Named) error {
+	if action == config.ActionRemove {
+		return config.RemoveCharger(cur.Name)
+	}
+
+	instance, err := newChargerOrPlugin(next.Type, next.Other)
+	if err != nil {
+		return fmt.Errorf("cannot create charger '%s': %w", next.Name, err)
+	}
+
+	dev := config.NewStaticDevice[api.Charger](next)
+	dev.Connect(instance)
+
+	if action == config.ActionUpdate {
+		return config.UpdateCharger(cur.Name, dev)
+	}
+
+	return config.AddCharger(dev)
+}
+
+func (setupApplier) ApplyVehicle(action config.Action, cur, next config.Named) error {
+	if action == config.ActionRemove {
+		return config.RemoveVehicle(cur.Name)
+	}
+
+	instance, err := newVehicleOrPlugin(next.Type, next.Other)
+	if err != nil {
+		return fmt.Errorf("cannot create vehicle '%s': %w", next.Name, err)
+	}
+
+	dev := config.NewStaticDevice[api.Vehicle](next)
+	dev.Connect(instance)
+
+	if action == config.ActionUpdate {
+		return config.UpdateVehicle(cur.Name, dev)
+	}
+
+	return config.AddVehicle(dev)
+}
+
+// ApplyLoadpoints and ApplyTariffs are not yet wired to core.Site's
+// configuration; site-level hot reload is tracked as follow-up work.
+func (setupApplier) ApplyLoadpoints(conf []map[string]any) error {
+	log.WARN.Println("loadpoint configuration changed; reload requires a restart")
+	return nil
+}
+
+func (setupApplier) ApplyTariffs(conf map[string]any) error {
+	log.WARN.Println("tariff configuration changed; reload requires a restart")
+	return nil
+}
+
 // configureDatabase configures session database
 func configureDatabase(conf dbConfig) error {
 	if err := db.NewInstance(conf.Type, conf.Dsn); err != nil {
@@ -410,15 +646,19 @@ func configureInflux(conf server.InfluxConfig, site site.API, in <-chan util.Par
 // setup mqtt
 func configureMQTT(conf mqttConfig) error {
 	log := util.NewLogger("mqtt")
+	devLog := hclog.New("mqtt", hclog.Fields{"broker": conf.Broker})
 
 	var err error
 	if mqtt.Instance, err = mqtt.RegisteredClient(log, conf.Broker, conf.User, conf.Password, conf.ClientID, 1, conf.Insecure, func(options *paho.ClientOptions) {
 		topic := fmt.Sprintf("%s/status", strings.Trim(conf.Topic, "/"))
 		options.SetWill(topic, "offline", 1, true)
 	}); err != nil {
+		devLog.ERROR.Printf("connecting failed: %v", err)
 		return fmt.Errorf("failed configuring mqtt: %w", err)
 	}
 
+	devLog.INFO.Println("connected")
+
 	return nil
 }
 
@@ -601,8 +841,11 @@ func configureLoadpoints(conf globalConfig) (loadpoints []*core.Loadpoint, err e
 		}
 
 		log := util.NewLogger("lp-" + strconv.Itoa(id+1))
+		devLog := hclog.New("loadpoint", hclog.Fields{"loadpoint": id + 1})
+
 		lp, err := core.NewLoadpointFromConfig(log, lpc)
 		if err != nil {
+			devLog.ERROR.Printf("configuring loadpoint failed: %v", err)
 			return nil, fmt.Errorf("failed configuring loadpoint: %w", err)
 		}
 
@@ -656,4 +899,65 @@ func configureAuth(conf networkConfig, vehicles []api.Vehicle, router *mux.Route
 	}
 
 	authCollection.Publish()
+
+	configureConfigSnapshotAPI(router)
+}
+
+// configureConfigSnapshotAPI wires the device configuration snapshot and
+// restore endpoints under /api/config. restoreHandler replaces the entire
+// device database, so both routes sit behind requireSnapshotToken rather
+// than the unauthenticated /config prefix they first shipped under.
+func configureConfigSnapshotAPI(router *mux.Router) {
+	snap := router.PathPrefix("/api/config").Subrouter()
+	snap.Use(requireSnapshotToken(conf.SnapshotToken))
+
+	snap.Methods(http.MethodGet).Path("/snapshot").HandlerFunc(snapshotHandler)
+	snap.Methods(http.MethodPost).Path("/restore").HandlerFunc(restoreHandler)
+}
+
+// requireSnapshotToken rejects any request whose `Authorization: Bearer
+// <token>` header does not match token. An empty token - the default -
+// rejects every request, so the snapshot/restore routes stay unreachable
+// until an operator opts in by setting `snapshotToken:` in the config
+// file, instead of defaulting to open.
+func requireSnapshotToken(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" || r.Header.Get("Authorization") != "Bearer "+token {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// snapshotHandler serves the current device configuration as a tar+json
+// archive suitable for config.Restore. See config.Snapshot for the
+// archive's scope.
+func snapshotHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", `attachment; filename="evcc-snapshot.tar"`)
+
+	if err := config.Snapshot(w); err != nil {
+		log.ERROR.Printf("snapshot: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// restoreHandler replaces the device configuration database with the
+// uploaded archive. A restart is still required to pick up the restored
+// devices until the config.Aggregator reload path covers this case too.
+func restoreHandler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if err := config.Restore(r.Body); err != nil {
+		log.ERROR.Printf("restore: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.INFO.Println("configuration restored from snapshot; restart evcc to apply")
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// snapshotCmd groups the offline snapshot save/restore subcommands, which
+// work directly against the configured sqlite DSN without a running
+// server.
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save or restore the device configuration database",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save [file]",
+	Short: "Write a device configuration snapshot to file",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSnapshotSave,
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "Restore the device configuration database from a snapshot",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSnapshotRestore,
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// openSnapshotDB opens the configured database without starting the rest
+// of evcc, so snapshot save/restore can run without a live server.
+func openSnapshotDB() error {
+	viper.SetConfigFile(cfgFile)
+	_ = viper.ReadInConfig()
+
+	if err := viper.UnmarshalExact(&conf); err != nil {
+		return err
+	}
+
+	if err := db.NewInstance(conf.Database.Type, conf.Database.Dsn); err != nil {
+		return err
+	}
+
+	return config.Init(db.Instance)
+}
+
+func runSnapshotSave(cmd *cobra.Command, args []string) {
+	if err := openSnapshotDB(); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := config.Snapshot(f); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	log.INFO.Printf("wrote snapshot to %s", args[0])
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) {
+	if err := openSnapshotDB(); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := config.Restore(f); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	log.INFO.Printf("restored configuration from %s", args[0])
+}
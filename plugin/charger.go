@@ -0,0 +1,219 @@
+package plugin
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// chargerRPC is the RPC surface a plugin implements for the "charger"
+// capability. It mirrors api.Charger plus the optional capability
+// interfaces evcc negotiates at handshake time.
+type chargerRPC interface {
+	Status() (api.ChargeStatus, error)
+	Enabled() (bool, error)
+	Enable(enable bool) error
+	MaxCurrent(current int64) error
+
+	// capability negotiation
+	Capabilities() ([]string, error)
+
+	// api.ChargePhases
+	Phases1p3p(phases int) error
+
+	// api.Battery
+	Soc() (float64, error)
+
+	// api.MeterEnergy
+	TotalEnergy() (float64, error)
+}
+
+// chargerPlugin implements goplugin.Plugin for the "charger" capability.
+type chargerPlugin struct {
+	Impl chargerRPC
+}
+
+// chargerProxy adapts a plugin's RPC client to api.Charger. Capability
+// interfaces (api.ChargePhases, api.Battery, api.MeterEnergy, ...) are only
+// exposed via NewCharger once the plugin has advertised them, so a type
+// assertion against the returned api.Charger behaves exactly as if the
+// capability had been implemented in-process.
+//
+// rpc is held behind a dispenser rather than a bound chargerRPC value so a
+// plugin restart re-points every live proxy at the fresh connection
+// instead of leaving them talking to a dead client.
+type chargerProxy struct {
+	disp *dispenser
+}
+
+func (p *chargerProxy) rpc() (chargerRPC, error) {
+	raw, err := p.disp.get()
+	if err != nil {
+		return nil, err
+	}
+	return raw.(chargerRPC), nil
+}
+
+func (p *chargerProxy) Status() (api.ChargeStatus, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return "", err
+	}
+	return rpc.Status()
+}
+
+func (p *chargerProxy) Enabled() (bool, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return false, err
+	}
+	return rpc.Enabled()
+}
+
+func (p *chargerProxy) Enable(enable bool) error {
+	rpc, err := p.rpc()
+	if err != nil {
+		return err
+	}
+	return rpc.Enable(enable)
+}
+
+func (p *chargerProxy) MaxCurrent(current int64) error {
+	rpc, err := p.rpc()
+	if err != nil {
+		return err
+	}
+	return rpc.MaxCurrent(current)
+}
+
+// chargerPhasesProxy additionally exposes api.ChargePhases.
+type chargerPhasesProxy struct {
+	*chargerProxy
+}
+
+func (p *chargerPhasesProxy) Phases1p3p(phases int) error {
+	rpc, err := p.rpc()
+	if err != nil {
+		return err
+	}
+	return rpc.Phases1p3p(phases)
+}
+
+// chargerBatteryProxy additionally exposes api.Battery.
+type chargerBatteryProxy struct {
+	*chargerProxy
+}
+
+func (p *chargerBatteryProxy) Soc() (float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.Soc()
+}
+
+// chargerMeterEnergyProxy additionally exposes api.MeterEnergy.
+type chargerMeterEnergyProxy struct {
+	*chargerProxy
+}
+
+func (p *chargerMeterEnergyProxy) TotalEnergy() (float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.TotalEnergy()
+}
+
+// NewCharger dispenses the "charger" capability from the supervised plugin
+// and wraps it in the narrowest combination of proxies the plugin actually
+// advertises, so unsupported capability interfaces are not visible on the
+// returned api.Charger via type assertion.
+func NewCharger(cc Config) (api.Charger, error) {
+	sup, err := start(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHandle(sup)
+	go h.supervise(cc)
+
+	disp := newDispenser(h, "charger")
+
+	raw, err := disp.get()
+	if err != nil {
+		return nil, err
+	}
+
+	caps, err := raw.(chargerRPC).Capabilities()
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, api.ErrNotAvailable
+		}
+		return nil, err
+	}
+
+	base := &chargerProxy{disp: disp}
+
+	return decorateCharger(base, caps), nil
+}
+
+// decorateCharger composes the capability-specific proxies the plugin
+// advertised on top of the base api.Charger implementation. Every one of
+// the 2^3 combinations of phases/battery/energy is enumerated explicitly
+// so a plugin advertising any subset gets exactly that subset on the
+// returned api.Charger - no capability is ever silently dropped.
+func decorateCharger(base *chargerProxy, caps []string) api.Charger {
+	has := make(map[string]bool, len(caps))
+	for _, c := range caps {
+		has[c] = true
+	}
+
+	phases, battery, energy := has["phases"], has["battery"], has["energy"]
+
+	switch {
+	case phases && battery && energy:
+		return struct {
+			api.Charger
+			api.ChargePhases
+			api.Battery
+			api.MeterEnergy
+		}{base, &chargerPhasesProxy{base}, &chargerBatteryProxy{base}, &chargerMeterEnergyProxy{base}}
+	case phases && battery:
+		return struct {
+			api.Charger
+			api.ChargePhases
+			api.Battery
+		}{base, &chargerPhasesProxy{base}, &chargerBatteryProxy{base}}
+	case phases && energy:
+		return struct {
+			api.Charger
+			api.ChargePhases
+			api.MeterEnergy
+		}{base, &chargerPhasesProxy{base}, &chargerMeterEnergyProxy{base}}
+	case battery && energy:
+		return struct {
+			api.Charger
+			api.Battery
+			api.MeterEnergy
+		}{base, &chargerBatteryProxy{base}, &chargerMeterEnergyProxy{base}}
+	case phases:
+		return struct {
+			api.Charger
+			api.ChargePhases
+		}{base, &chargerPhasesProxy{base}}
+	case battery:
+		return struct {
+			api.Charger
+			api.Battery
+		}{base, &chargerBatteryProxy{base}}
+	case energy:
+		return struct {
+			api.Charger
+			api.MeterEnergy
+		}{base, &chargerMeterEnergyProxy{base}}
+	default:
+		return base
+	}
+}
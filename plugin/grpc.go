@@ -0,0 +1,207 @@
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/evcc-io/evcc/api"
+	pb "github.com/evcc-io/evcc/plugin/proto"
+)
+
+// The GRPCClient/GRPCServer pairs below implement goplugin.GRPCPlugin.
+// pb is generated from proto/plugin.proto via `make plugin-proto`; only the
+// client side is used today since evcc is always the plugin host, never the
+// plugin itself.
+
+func (p *chargerPlugin) GRPCServer(_ interface{}, s *grpc.Server) error {
+	return nil // evcc only ever hosts, never implements, the charger service
+}
+
+func (p *chargerPlugin) GRPCClient(_ context.Context, _ interface{}, conn *grpc.ClientConn) (interface{}, error) {
+	return &chargerClient{client: pb.NewChargerClient(conn)}, nil
+}
+
+func (p *meterPlugin) GRPCServer(_ interface{}, s *grpc.Server) error {
+	return nil
+}
+
+func (p *meterPlugin) GRPCClient(_ context.Context, _ interface{}, conn *grpc.ClientConn) (interface{}, error) {
+	return &meterClient{client: pb.NewMeterClient(conn)}, nil
+}
+
+func (p *vehiclePlugin) GRPCServer(_ interface{}, s *grpc.Server) error {
+	return nil
+}
+
+func (p *vehiclePlugin) GRPCClient(_ context.Context, _ interface{}, conn *grpc.ClientConn) (interface{}, error) {
+	return &vehicleClient{client: pb.NewVehicleClient(conn)}, nil
+}
+
+// chargerClient adapts the generated gRPC client to the chargerRPC
+// interface used by chargerProxy.
+type chargerClient struct {
+	client pb.ChargerClient
+}
+
+func (c *chargerClient) Capabilities() ([]string, error) {
+	reply, err := c.client.Capabilities(context.Background(), new(pb.Empty))
+	if err != nil {
+		return nil, err
+	}
+	return reply.Capabilities, nil
+}
+
+func (c *chargerClient) Status() (api.ChargeStatus, error) {
+	reply, err := c.client.Status(context.Background(), new(pb.Empty))
+	if err != nil {
+		return "", err
+	}
+	return api.ChargeStatus(reply.Status), nil
+}
+
+func (c *chargerClient) Enabled() (bool, error) {
+	reply, err := c.client.Enabled(context.Background(), new(pb.Empty))
+	if err != nil {
+		return false, err
+	}
+	return reply.Value, nil
+}
+
+func (c *chargerClient) Enable(enable bool) error {
+	_, err := c.client.Enable(context.Background(), &pb.BoolRequest{Value: enable})
+	return err
+}
+
+func (c *chargerClient) MaxCurrent(current int64) error {
+	_, err := c.client.MaxCurrent(context.Background(), &pb.Int64Request{Value: current})
+	return err
+}
+
+func (c *chargerClient) Phases1p3p(phases int) error {
+	_, err := c.client.Phases1p3p(context.Background(), &pb.Int64Request{Value: int64(phases)})
+	return err
+}
+
+func (c *chargerClient) Soc() (float64, error) {
+	reply, err := c.client.Soc(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+func (c *chargerClient) TotalEnergy() (float64, error) {
+	reply, err := c.client.TotalEnergy(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+// meterClient adapts the generated gRPC client to the meterRPC interface.
+type meterClient struct {
+	client pb.MeterClient
+}
+
+func (c *meterClient) Capabilities() ([]string, error) {
+	reply, err := c.client.Capabilities(context.Background(), new(pb.Empty))
+	if err != nil {
+		return nil, err
+	}
+	return reply.Capabilities, nil
+}
+
+func (c *meterClient) CurrentPower() (float64, error) {
+	reply, err := c.client.CurrentPower(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+func (c *meterClient) TotalEnergy() (float64, error) {
+	reply, err := c.client.TotalEnergy(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+func (c *meterClient) Currents() (float64, float64, float64, error) {
+	reply, err := c.client.Currents(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return reply.L1, reply.L2, reply.L3, nil
+}
+
+func (c *meterClient) Soc() (float64, error) {
+	reply, err := c.client.Soc(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+// vehicleClient adapts the generated gRPC client to the vehicleRPC
+// interface.
+type vehicleClient struct {
+	client pb.VehicleClient
+}
+
+func (c *vehicleClient) Capabilities() ([]string, error) {
+	reply, err := c.client.Capabilities(context.Background(), new(pb.Empty))
+	if err != nil {
+		return nil, err
+	}
+	return reply.Capabilities, nil
+}
+
+func (c *vehicleClient) Title() string {
+	reply, _ := c.client.Title(context.Background(), new(pb.Empty))
+	if reply == nil {
+		return ""
+	}
+	return reply.Value
+}
+
+func (c *vehicleClient) Icon() string {
+	reply, _ := c.client.Icon(context.Background(), new(pb.Empty))
+	if reply == nil {
+		return ""
+	}
+	return reply.Value
+}
+
+func (c *vehicleClient) Capacity() float64 {
+	reply, _ := c.client.Capacity(context.Background(), new(pb.Empty))
+	if reply == nil {
+		return 0
+	}
+	return reply.Value
+}
+
+func (c *vehicleClient) Soc() (float64, error) {
+	reply, err := c.client.Soc(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+func (c *vehicleClient) Range() (int64, error) {
+	reply, err := c.client.Range(context.Background(), new(pb.Empty))
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
+}
+
+func (c *vehicleClient) Status() (api.ChargeStatus, error) {
+	reply, err := c.client.Status(context.Background(), new(pb.Empty))
+	if err != nil {
+		return "", err
+	}
+	return api.ChargeStatus(reply.Status), nil
+}
@@ -0,0 +1,32 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// newHclogAdapter bridges evcc's util.Logger to the hclog.Logger interface
+// go-plugin requires for handshake and child-process log forwarding.
+func newHclogAdapter(name string, log *util.Logger) hclog.Logger {
+	return hclog.New(&hclog.LoggerOptions{
+		Name:   name,
+		Output: logWriter{log},
+		Level:  hclog.Debug,
+	})
+}
+
+// logWriter forwards raw plugin stderr/stdout lines into the parent's
+// logger at INFO level.
+type logWriter struct {
+	log *util.Logger
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.log.INFO.Print(string(p))
+	return len(p), nil
+}
+
+var _ io.Writer = logWriter{}
@@ -0,0 +1,176 @@
+package plugin
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// meterRPC is the RPC surface a plugin implements for the "meter"
+// capability.
+type meterRPC interface {
+	CurrentPower() (float64, error)
+
+	Capabilities() ([]string, error)
+
+	// api.MeterEnergy
+	TotalEnergy() (float64, error)
+
+	// api.PhaseCurrents
+	Currents() (float64, float64, float64, error)
+
+	// api.Battery
+	Soc() (float64, error)
+}
+
+// meterPlugin implements goplugin.Plugin for the "meter" capability.
+type meterPlugin struct {
+	Impl meterRPC
+}
+
+// meterProxy adapts a plugin's RPC client to api.Meter. See chargerProxy
+// for why rpc is resolved through a dispenser instead of a bound value.
+type meterProxy struct {
+	disp *dispenser
+}
+
+func (p *meterProxy) rpc() (meterRPC, error) {
+	raw, err := p.disp.get()
+	if err != nil {
+		return nil, err
+	}
+	return raw.(meterRPC), nil
+}
+
+func (p *meterProxy) CurrentPower() (float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.CurrentPower()
+}
+
+type meterEnergyProxy struct {
+	*meterProxy
+}
+
+func (p *meterEnergyProxy) TotalEnergy() (float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.TotalEnergy()
+}
+
+type meterCurrentsProxy struct {
+	*meterProxy
+}
+
+func (p *meterCurrentsProxy) Currents() (float64, float64, float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return rpc.Currents()
+}
+
+type meterBatteryProxy struct {
+	*meterProxy
+}
+
+func (p *meterBatteryProxy) Soc() (float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.Soc()
+}
+
+// NewMeter dispenses the "meter" capability from the supervised plugin and
+// wraps it in the combination of proxies the plugin advertises.
+func NewMeter(cc Config) (api.Meter, error) {
+	sup, err := start(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHandle(sup)
+	go h.supervise(cc)
+
+	disp := newDispenser(h, "meter")
+
+	raw, err := disp.get()
+	if err != nil {
+		return nil, err
+	}
+
+	caps, err := raw.(meterRPC).Capabilities()
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, api.ErrNotAvailable
+		}
+		return nil, err
+	}
+
+	base := &meterProxy{disp: disp}
+
+	return decorateMeter(base, caps), nil
+}
+
+// decorateMeter composes the capability-specific proxies the plugin
+// advertised. Every one of the 2^3 combinations of energy/currents/battery
+// is enumerated explicitly so no advertised capability is ever dropped.
+func decorateMeter(base *meterProxy, caps []string) api.Meter {
+	has := make(map[string]bool, len(caps))
+	for _, c := range caps {
+		has[c] = true
+	}
+
+	energy, currents, battery := has["energy"], has["currents"], has["battery"]
+
+	switch {
+	case energy && currents && battery:
+		return struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.Battery
+		}{base, &meterEnergyProxy{base}, &meterCurrentsProxy{base}, &meterBatteryProxy{base}}
+	case energy && currents:
+		return struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+		}{base, &meterEnergyProxy{base}, &meterCurrentsProxy{base}}
+	case energy && battery:
+		return struct {
+			api.Meter
+			api.MeterEnergy
+			api.Battery
+		}{base, &meterEnergyProxy{base}, &meterBatteryProxy{base}}
+	case currents && battery:
+		return struct {
+			api.Meter
+			api.PhaseCurrents
+			api.Battery
+		}{base, &meterCurrentsProxy{base}, &meterBatteryProxy{base}}
+	case energy:
+		return struct {
+			api.Meter
+			api.MeterEnergy
+		}{base, &meterEnergyProxy{base}}
+	case currents:
+		return struct {
+			api.Meter
+			api.PhaseCurrents
+		}{base, &meterCurrentsProxy{base}}
+	case battery:
+		return struct {
+			api.Meter
+			api.Battery
+		}{base, &meterBatteryProxy{base}}
+	default:
+		return base
+	}
+}
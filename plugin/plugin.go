@@ -0,0 +1,205 @@
+// Package plugin launches and supervises out-of-process drivers for chargers,
+// meters and vehicles, and adapts the resulting RPC client to evcc's api
+// interfaces. Plugins are ordinary binaries speaking the hashicorp/go-plugin
+// gRPC handshake, so closed-source or community-maintained drivers can ship
+// without recompiling evcc.
+package plugin
+
+import (
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/evcc-io/evcc/cmd/shutdown"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Handshake is shared between evcc and all plugin binaries. The version must
+// be bumped whenever the RPC surface changes in an incompatible way.
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "EVCC_PLUGIN",
+	MagicCookieValue: "driver",
+}
+
+// Config describes a single plugin binary as configured under the top-level
+// `plugins:` list.
+type Config struct {
+	Name string
+	Cmd  string
+	Args []string
+	Env  []string
+}
+
+// pluginMap advertises every capability evcc knows how to speak to a plugin
+// about. A plugin only needs to implement the entries it supports; which
+// ones it actually implements is negotiated at handshake time.
+var pluginMap = map[string]goplugin.Plugin{
+	"charger": &chargerPlugin{},
+	"meter":   &meterPlugin{},
+	"vehicle": &vehiclePlugin{},
+}
+
+// supervisor manages a single plugin's child process, restarting it with
+// backoff and forwarding its logs into evcc's logger namespace.
+type supervisor struct {
+	log    *util.Logger
+	client *goplugin.Client
+}
+
+// start launches the plugin binary and performs the go-plugin handshake.
+func start(cc Config) (*supervisor, error) {
+	log := util.NewLogger("plugin-" + cc.Name)
+
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         pluginMap,
+		Cmd:             exec.Command(cc.Cmd, cc.Args...),
+		AllowedProtocols: []goplugin.Protocol{
+			goplugin.ProtocolGRPC,
+		},
+		Logger: newHclogAdapter("plugin-"+cc.Name, log),
+	})
+
+	if _, err := client.Client(); err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("plugin '%s' handshake failed: %w", cc.Name, err)
+	}
+
+	s := &supervisor{log: log, client: client}
+
+	shutdown.Register(s.client.Kill)
+
+	return s, nil
+}
+
+// handle holds the currently active supervisor for a plugin and a generation
+// counter that is bumped every time supervise swaps in a new one after a
+// restart. dispensers compare their cached generation against current() to
+// notice a restart happened and re-dispense against the fresh client,
+// instead of going on talking to the dead one.
+type handle struct {
+	mu  sync.RWMutex
+	sup *supervisor
+	gen int
+}
+
+// newHandle wraps sup as the initial generation of a supervised plugin.
+func newHandle(sup *supervisor) *handle {
+	return &handle{sup: sup}
+}
+
+// current returns the active supervisor and its generation.
+func (h *handle) current() (*supervisor, int) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.sup, h.gen
+}
+
+// replace installs sup as the new active supervisor and bumps the
+// generation so cached dispensers know to re-resolve.
+func (h *handle) replace(sup *supervisor) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sup = sup
+	h.gen++
+}
+
+// dispense requests the named capability ("charger", "meter" or "vehicle")
+// from the currently active plugin process. A non-nil error is always a
+// real handshake or transport failure; whether the plugin advertises the
+// capability at all is established separately via the RPC's Capabilities
+// method, not by this call failing.
+func (h *handle) dispense(kind string) (raw interface{}, gen int, err error) {
+	sup, gen := h.current()
+
+	rpcClient, err := sup.client.Client()
+	if err != nil {
+		return nil, gen, err
+	}
+
+	raw, err = rpcClient.Dispense(kind)
+	if err != nil {
+		return nil, gen, fmt.Errorf("dispense '%s': %w", kind, err)
+	}
+
+	return raw, gen, nil
+}
+
+// supervise polls the child process and restarts it with exponential
+// backoff if it exits unexpectedly, re-pointing h at the new supervisor so
+// every dispenser backed by h follows the restart instead of being left
+// holding a client for the dead process.
+func (h *handle) supervise(cc Config) {
+	const pollInterval = 5 * time.Second
+	backoff := time.Second
+
+	for {
+		time.Sleep(pollInterval)
+
+		sup, _ := h.current()
+		if !sup.client.Exited() {
+			continue
+		}
+
+		sup.log.ERROR.Printf("plugin '%s' exited, restarting in %s", cc.Name, backoff)
+		time.Sleep(backoff)
+
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+
+		next, err := start(cc)
+		if err != nil {
+			sup.log.ERROR.Printf("plugin '%s' restart failed: %v", cc.Name, err)
+			continue
+		}
+
+		h.replace(next)
+		backoff = time.Second
+	}
+}
+
+// dispenser caches the RPC value dispensed for a single capability and
+// re-dispenses it whenever h's generation moves on, so a proxy built on top
+// of it automatically follows a plugin restart instead of calling methods
+// on an rpc client left over from the previous process.
+type dispenser struct {
+	h    *handle
+	kind string
+
+	mu  sync.Mutex
+	gen int
+	raw interface{}
+	ok  bool
+}
+
+// newDispenser caches dispenses of kind against h.
+func newDispenser(h *handle, kind string) *dispenser {
+	return &dispenser{h: h, kind: kind, gen: -1}
+}
+
+// get returns the cached RPC value for the handle's current generation,
+// re-dispensing it if the plugin has restarted since it was last resolved.
+func (d *dispenser) get() (interface{}, error) {
+	_, gen := d.h.current()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.ok && d.gen == gen {
+		return d.raw, nil
+	}
+
+	raw, gen, err := d.h.dispense(d.kind)
+	if err != nil {
+		return nil, err
+	}
+
+	d.raw, d.gen, d.ok = raw, gen, true
+
+	return d.raw, nil
+}
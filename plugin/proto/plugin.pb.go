@@ -0,0 +1,181 @@
+// Hand-written shim standing in for protoc-gen-go output, kept in sync
+// with plugin.proto by hand until protoc is available to regenerate it
+// via `make plugin-proto` (see generate.go). Do not mistake this for
+// real generated code: it implements just enough of the legacy
+// github.com/golang/protobuf/proto surface (Reset/String/ProtoMessage)
+// for plugin_grpc.pb.go to compile against, not the full descriptor-based
+// reflection real protoc-gen-go output carries.
+// source: plugin.proto
+
+package proto
+
+import (
+	fmt "fmt"
+
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+
+type Empty struct{}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return "Empty{}" }
+func (*Empty) ProtoMessage()    {}
+
+type BoolRequest struct {
+	Value bool `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *BoolRequest) Reset()         { *m = BoolRequest{} }
+func (m *BoolRequest) String() string { return fmt.Sprintf("BoolRequest{Value:%v}", m.Value) }
+func (*BoolRequest) ProtoMessage()    {}
+
+func (m *BoolRequest) GetValue() bool {
+	if m != nil {
+		return m.Value
+	}
+	return false
+}
+
+type BoolReply struct {
+	Value bool `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *BoolReply) Reset()         { *m = BoolReply{} }
+func (m *BoolReply) String() string { return fmt.Sprintf("BoolReply{Value:%v}", m.Value) }
+func (*BoolReply) ProtoMessage()    {}
+
+func (m *BoolReply) GetValue() bool {
+	if m != nil {
+		return m.Value
+	}
+	return false
+}
+
+type Int64Request struct {
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Int64Request) Reset()         { *m = Int64Request{} }
+func (m *Int64Request) String() string { return fmt.Sprintf("Int64Request{Value:%v}", m.Value) }
+func (*Int64Request) ProtoMessage()    {}
+
+func (m *Int64Request) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type Int64Reply struct {
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Int64Reply) Reset()         { *m = Int64Reply{} }
+func (m *Int64Reply) String() string { return fmt.Sprintf("Int64Reply{Value:%v}", m.Value) }
+func (*Int64Reply) ProtoMessage()    {}
+
+func (m *Int64Reply) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type Float64Reply struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Float64Reply) Reset()         { *m = Float64Reply{} }
+func (m *Float64Reply) String() string { return fmt.Sprintf("Float64Reply{Value:%v}", m.Value) }
+func (*Float64Reply) ProtoMessage()    {}
+
+func (m *Float64Reply) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type Float64x3Reply struct {
+	L1 float64 `protobuf:"fixed64,1,opt,name=l1,proto3" json:"l1,omitempty"`
+	L2 float64 `protobuf:"fixed64,2,opt,name=l2,proto3" json:"l2,omitempty"`
+	L3 float64 `protobuf:"fixed64,3,opt,name=l3,proto3" json:"l3,omitempty"`
+}
+
+func (m *Float64x3Reply) Reset() { *m = Float64x3Reply{} }
+func (m *Float64x3Reply) String() string {
+	return fmt.Sprintf("Float64x3Reply{L1:%v, L2:%v, L3:%v}", m.L1, m.L2, m.L3)
+}
+func (*Float64x3Reply) ProtoMessage() {}
+
+func (m *Float64x3Reply) GetL1() float64 {
+	if m != nil {
+		return m.L1
+	}
+	return 0
+}
+
+func (m *Float64x3Reply) GetL2() float64 {
+	if m != nil {
+		return m.L2
+	}
+	return 0
+}
+
+func (m *Float64x3Reply) GetL3() float64 {
+	if m != nil {
+		return m.L3
+	}
+	return 0
+}
+
+type StringReply struct {
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *StringReply) Reset()         { *m = StringReply{} }
+func (m *StringReply) String() string { return fmt.Sprintf("StringReply{Value:%q}", m.Value) }
+func (*StringReply) ProtoMessage()    {}
+
+func (m *StringReply) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type StatusReply struct {
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *StatusReply) Reset()         { *m = StatusReply{} }
+func (m *StatusReply) String() string { return fmt.Sprintf("StatusReply{Status:%q}", m.Status) }
+func (*StatusReply) ProtoMessage()    {}
+
+func (m *StatusReply) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type CapabilitiesReply struct {
+	Capabilities []string `protobuf:"bytes,1,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+}
+
+func (m *CapabilitiesReply) Reset() { *m = CapabilitiesReply{} }
+func (m *CapabilitiesReply) String() string {
+	return fmt.Sprintf("CapabilitiesReply{Capabilities:%v}", m.Capabilities)
+}
+func (*CapabilitiesReply) ProtoMessage() {}
+
+func (m *CapabilitiesReply) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
@@ -0,0 +1,372 @@
+// Hand-written shim standing in for protoc-gen-go-grpc output, kept in
+// sync with plugin.proto by hand until protoc is available to
+// regenerate it via `make plugin-proto` (see generate.go). The client/
+// server surface below matches what real protoc-gen-go-grpc would emit,
+// but do not mistake this file for genuine generated code.
+// source: plugin.proto
+
+package proto
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// ChargerClient is the client API for Charger service.
+type ChargerClient interface {
+	Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesReply, error)
+	Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusReply, error)
+	Enabled(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BoolReply, error)
+	Enable(ctx context.Context, in *BoolRequest, opts ...grpc.CallOption) (*Empty, error)
+	MaxCurrent(ctx context.Context, in *Int64Request, opts ...grpc.CallOption) (*Empty, error)
+	Phases1p3p(ctx context.Context, in *Int64Request, opts ...grpc.CallOption) (*Empty, error)
+	Soc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+	TotalEnergy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+}
+
+type chargerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChargerClient(cc grpc.ClientConnInterface) ChargerClient {
+	return &chargerClient{cc}
+}
+
+func (c *chargerClient) Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesReply, error) {
+	out := new(CapabilitiesReply)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/Capabilities", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/Status", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) Enabled(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/Enabled", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) Enable(ctx context.Context, in *BoolRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/Enable", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) MaxCurrent(ctx context.Context, in *Int64Request, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/MaxCurrent", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) Phases1p3p(ctx context.Context, in *Int64Request, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/Phases1p3p", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) Soc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/Soc", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chargerClient) TotalEnergy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Charger/TotalEnergy", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChargerServer is the server API for Charger service. evcc only ever hosts
+// plugins, it never implements one, so UnimplementedChargerServer is the
+// only implementation shipped; it exists so the service is a valid
+// goplugin.GRPCPlugin pair even though GRPCServer is a no-op.
+type ChargerServer interface {
+	Capabilities(context.Context, *Empty) (*CapabilitiesReply, error)
+	Status(context.Context, *Empty) (*StatusReply, error)
+	Enabled(context.Context, *Empty) (*BoolReply, error)
+	Enable(context.Context, *BoolRequest) (*Empty, error)
+	MaxCurrent(context.Context, *Int64Request) (*Empty, error)
+	Phases1p3p(context.Context, *Int64Request) (*Empty, error)
+	Soc(context.Context, *Empty) (*Float64Reply, error)
+	TotalEnergy(context.Context, *Empty) (*Float64Reply, error)
+}
+
+type UnimplementedChargerServer struct{}
+
+func (UnimplementedChargerServer) Capabilities(context.Context, *Empty) (*CapabilitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+func (UnimplementedChargerServer) Status(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedChargerServer) Enabled(context.Context, *Empty) (*BoolReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enabled not implemented")
+}
+func (UnimplementedChargerServer) Enable(context.Context, *BoolRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enable not implemented")
+}
+func (UnimplementedChargerServer) MaxCurrent(context.Context, *Int64Request) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MaxCurrent not implemented")
+}
+func (UnimplementedChargerServer) Phases1p3p(context.Context, *Int64Request) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Phases1p3p not implemented")
+}
+func (UnimplementedChargerServer) Soc(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Soc not implemented")
+}
+func (UnimplementedChargerServer) TotalEnergy(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TotalEnergy not implemented")
+}
+
+func RegisterChargerServer(s grpc.ServiceRegistrar, srv ChargerServer) {
+	s.RegisterService(&Charger_ServiceDesc, srv)
+}
+
+var Charger_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Charger",
+	HandlerType: (*ChargerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams:     []grpc.StreamDesc{},
+	Metadata:    "plugin.proto",
+}
+
+// MeterClient is the client API for Meter service.
+type MeterClient interface {
+	Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesReply, error)
+	CurrentPower(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+	TotalEnergy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+	Currents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64x3Reply, error)
+	Soc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+}
+
+type meterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMeterClient(cc grpc.ClientConnInterface) MeterClient {
+	return &meterClient{cc}
+}
+
+func (c *meterClient) Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesReply, error) {
+	out := new(CapabilitiesReply)
+	if err := c.cc.Invoke(ctx, "/proto.Meter/Capabilities", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meterClient) CurrentPower(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Meter/CurrentPower", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meterClient) TotalEnergy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Meter/TotalEnergy", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meterClient) Currents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64x3Reply, error) {
+	out := new(Float64x3Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Meter/Currents", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meterClient) Soc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Meter/Soc", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MeterServer is the server API for Meter service.
+type MeterServer interface {
+	Capabilities(context.Context, *Empty) (*CapabilitiesReply, error)
+	CurrentPower(context.Context, *Empty) (*Float64Reply, error)
+	TotalEnergy(context.Context, *Empty) (*Float64Reply, error)
+	Currents(context.Context, *Empty) (*Float64x3Reply, error)
+	Soc(context.Context, *Empty) (*Float64Reply, error)
+}
+
+type UnimplementedMeterServer struct{}
+
+func (UnimplementedMeterServer) Capabilities(context.Context, *Empty) (*CapabilitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+func (UnimplementedMeterServer) CurrentPower(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CurrentPower not implemented")
+}
+func (UnimplementedMeterServer) TotalEnergy(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TotalEnergy not implemented")
+}
+func (UnimplementedMeterServer) Currents(context.Context, *Empty) (*Float64x3Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Currents not implemented")
+}
+func (UnimplementedMeterServer) Soc(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Soc not implemented")
+}
+
+func RegisterMeterServer(s grpc.ServiceRegistrar, srv MeterServer) {
+	s.RegisterService(&Meter_ServiceDesc, srv)
+}
+
+var Meter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Meter",
+	HandlerType: (*MeterServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams:     []grpc.StreamDesc{},
+	Metadata:    "plugin.proto",
+}
+
+// VehicleClient is the client API for Vehicle service.
+type VehicleClient interface {
+	Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesReply, error)
+	Title(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StringReply, error)
+	Icon(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StringReply, error)
+	Capacity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+	Soc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error)
+	Range(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Int64Reply, error)
+	Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusReply, error)
+}
+
+type vehicleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVehicleClient(cc grpc.ClientConnInterface) VehicleClient {
+	return &vehicleClient{cc}
+}
+
+func (c *vehicleClient) Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesReply, error) {
+	out := new(CapabilitiesReply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Capabilities", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleClient) Title(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StringReply, error) {
+	out := new(StringReply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Title", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleClient) Icon(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StringReply, error) {
+	out := new(StringReply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Icon", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleClient) Capacity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Capacity", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleClient) Soc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Float64Reply, error) {
+	out := new(Float64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Soc", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleClient) Range(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Int64Reply, error) {
+	out := new(Int64Reply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Range", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/proto.Vehicle/Status", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VehicleServer is the server API for Vehicle service.
+type VehicleServer interface {
+	Capabilities(context.Context, *Empty) (*CapabilitiesReply, error)
+	Title(context.Context, *Empty) (*StringReply, error)
+	Icon(context.Context, *Empty) (*StringReply, error)
+	Capacity(context.Context, *Empty) (*Float64Reply, error)
+	Soc(context.Context, *Empty) (*Float64Reply, error)
+	Range(context.Context, *Empty) (*Int64Reply, error)
+	Status(context.Context, *Empty) (*StatusReply, error)
+}
+
+type UnimplementedVehicleServer struct{}
+
+func (UnimplementedVehicleServer) Capabilities(context.Context, *Empty) (*CapabilitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+func (UnimplementedVehicleServer) Title(context.Context, *Empty) (*StringReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Title not implemented")
+}
+func (UnimplementedVehicleServer) Icon(context.Context, *Empty) (*StringReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Icon not implemented")
+}
+func (UnimplementedVehicleServer) Capacity(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capacity not implemented")
+}
+func (UnimplementedVehicleServer) Soc(context.Context, *Empty) (*Float64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Soc not implemented")
+}
+func (UnimplementedVehicleServer) Range(context.Context, *Empty) (*Int64Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Range not implemented")
+}
+func (UnimplementedVehicleServer) Status(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+
+func RegisterVehicleServer(s grpc.ServiceRegistrar, srv VehicleServer) {
+	s.RegisterService(&Vehicle_ServiceDesc, srv)
+}
+
+var Vehicle_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Vehicle",
+	HandlerType: (*VehicleServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams:     []grpc.StreamDesc{},
+	Metadata:    "plugin.proto",
+}
@@ -0,0 +1,210 @@
+package plugin
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// vehicleRPC is the RPC surface a plugin implements for the "vehicle"
+// capability.
+type vehicleRPC interface {
+	Title() string
+	Icon() string
+	Capacity() float64
+	Soc() (float64, error)
+
+	Capabilities() ([]string, error)
+
+	// api.VehicleRange
+	Range() (int64, error)
+
+	// api.ChargeState
+	Status() (api.ChargeStatus, error)
+}
+
+// vehiclePlugin implements goplugin.Plugin for the "vehicle" capability.
+type vehiclePlugin struct {
+	Impl vehicleRPC
+}
+
+// vehicleProxy adapts a plugin's RPC client to api.Vehicle. See
+// chargerProxy for why rpc is resolved through a dispenser instead of a
+// bound value. Unlike charger/meter, api.Vehicle carries a few fields
+// evcc itself owns rather than the device (configureVehicles calls SetTitle
+// with the configured name when the driver reports no title), so title is
+// cached locally instead of round tripping to the plugin.
+type vehicleProxy struct {
+	disp *dispenser
+
+	mu    sync.Mutex
+	title string
+}
+
+func (p *vehicleProxy) rpc() (vehicleRPC, error) {
+	raw, err := p.disp.get()
+	if err != nil {
+		return nil, err
+	}
+	return raw.(vehicleRPC), nil
+}
+
+func (p *vehicleProxy) Title() string {
+	p.mu.Lock()
+	title := p.title
+	p.mu.Unlock()
+
+	if title != "" {
+		return title
+	}
+
+	rpc, err := p.rpc()
+	if err != nil {
+		return ""
+	}
+	return rpc.Title()
+}
+
+// SetTitle overrides the title the plugin advertises, as configureVehicles
+// does with the configured name when the plugin reports no title.
+func (p *vehicleProxy) SetTitle(title string) {
+	p.mu.Lock()
+	p.title = title
+	p.mu.Unlock()
+}
+
+// Phases, Identifiers, OnIdentified and Features are not (yet) part of
+// the plugin vehicle protocol, so they report the same "unconfigured"
+// zero values a vehicle driver reports when it doesn't support them,
+// rather than round tripping to a plugin RPC that doesn't exist.
+
+func (p *vehicleProxy) Phases() int {
+	return 0
+}
+
+func (p *vehicleProxy) Identifiers() []string {
+	return nil
+}
+
+func (p *vehicleProxy) OnIdentified() api.ActionConfig {
+	return api.ActionConfig{}
+}
+
+func (p *vehicleProxy) Features() []api.Feature {
+	return nil
+}
+
+func (p *vehicleProxy) Icon() string {
+	rpc, err := p.rpc()
+	if err != nil {
+		return ""
+	}
+	return rpc.Icon()
+}
+
+func (p *vehicleProxy) Capacity() float64 {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0
+	}
+	return rpc.Capacity()
+}
+
+func (p *vehicleProxy) Soc() (float64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.Soc()
+}
+
+type vehicleRangeProxy struct {
+	*vehicleProxy
+}
+
+func (p *vehicleRangeProxy) Range() (int64, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return 0, err
+	}
+	return rpc.Range()
+}
+
+type vehicleStatusProxy struct {
+	*vehicleProxy
+}
+
+func (p *vehicleStatusProxy) Status() (api.ChargeStatus, error) {
+	rpc, err := p.rpc()
+	if err != nil {
+		return "", err
+	}
+	return rpc.Status()
+}
+
+// NewVehicle dispenses the "vehicle" capability from the supervised plugin
+// and wraps it in the combination of proxies the plugin advertises.
+func NewVehicle(cc Config) (api.Vehicle, error) {
+	sup, err := start(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHandle(sup)
+	go h.supervise(cc)
+
+	disp := newDispenser(h, "vehicle")
+
+	raw, err := disp.get()
+	if err != nil {
+		return nil, err
+	}
+
+	caps, err := raw.(vehicleRPC).Capabilities()
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, api.ErrNotAvailable
+		}
+		return nil, err
+	}
+
+	base := &vehicleProxy{disp: disp}
+
+	return decorateVehicle(base, caps), nil
+}
+
+// decorateVehicle composes the capability-specific proxies the plugin
+// advertised. Both range and status are booleans, so all 2^2 combinations
+// are enumerated explicitly.
+func decorateVehicle(base *vehicleProxy, caps []string) api.Vehicle {
+	has := make(map[string]bool, len(caps))
+	for _, c := range caps {
+		has[c] = true
+	}
+
+	rng, status := has["range"], has["status"]
+
+	switch {
+	case rng && status:
+		return struct {
+			api.Vehicle
+			api.VehicleRange
+			api.ChargeState
+		}{base, &vehicleRangeProxy{base}, &vehicleStatusProxy{base}}
+	case rng:
+		return struct {
+			api.Vehicle
+			api.VehicleRange
+		}{base, &vehicleRangeProxy{base}}
+	case status:
+		return struct {
+			api.Vehicle
+			api.ChargeState
+		}{base, &vehicleStatusProxy{base}}
+	default:
+		return base
+	}
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// Snapshot is a point-in-time view of the device, loadpoint and tariff
+// configuration as seen by a single Provider.
+type Snapshot struct {
+	Meters     []Named
+	Chargers   []Named
+	Vehicles   []Named
+	Loadpoints []map[string]any
+	Tariffs    map[string]any
+}
+
+// Provider pushes configuration snapshots onto the returned channel
+// whenever its backing source changes, until ctx is cancelled.
+// FileProvider (fsnotify-watched YAML) is the only implementation; the gorm
+// Config table, environment variables and remote sources have none.
+type Provider interface {
+	Subscribe(ctx context.Context) (<-chan Snapshot, error)
+}
+
+// Action describes the kind of change an Applier must make in response to
+// a diffed Snapshot.
+type Action int
+
+const (
+	ActionAdd Action = iota
+	ActionUpdate
+	ActionRemove
+)
+
+// Applier reconciles a running evcc instance with a new Snapshot without
+// requiring a process restart.
+type Applier interface {
+	ApplyMeter(action Action, cur, next Named) error
+	ApplyCharger(action Action, cur, next Named) error
+	ApplyVehicle(action Action, cur, next Named) error
+	ApplyLoadpoints(config []map[string]any) error
+	ApplyTariffs(config map[string]any) error
+}
+
+// Aggregator collects Snapshots from its Providers, debounces bursts of
+// change events, diffs the most recent Snapshot against the currently
+// applied configuration, and hands the diff to an Applier. Snapshots from
+// different Providers are not merged: whichever Provider published last
+// supplies the whole configuration that is applied. This is the
+// evcc equivalent of a provider aggregator: providers publish, the
+// aggregator decides what, if anything, actually changed.
+type Aggregator struct {
+	log       *util.Logger
+	providers []Provider
+	debounce  time.Duration
+	current   Snapshot
+}
+
+// NewAggregator creates an Aggregator over the given providers. debounce
+// coalesces bursts of snapshots (e.g. an editor doing multiple writes while
+// saving a file) into a single apply.
+func NewAggregator(log *util.Logger, debounce time.Duration, providers ...Provider) *Aggregator {
+	return &Aggregator{
+		log:       log,
+		providers: providers,
+		debounce:  debounce,
+	}
+}
+
+// Seed sets the Snapshot the first apply is diffed against. Call it once,
+// before Run, with whatever configuration was already applied while the
+// process was starting up - otherwise the first Snapshot a Provider
+// publishes (e.g. FileProvider's initial read) diffs against an empty
+// Aggregator and every boot-time device comes back as ActionAdd, duplicating
+// the devices configureMeters/configureChargers/configureVehicles already
+// registered.
+func (a *Aggregator) Seed(snapshot Snapshot) {
+	a.current = snapshot
+}
+
+// Run subscribes to all providers and applies debounced, diffed snapshots
+// to applier until ctx is cancelled.
+func (a *Aggregator) Run(ctx context.Context, applier Applier) error {
+	merged := make(chan Snapshot)
+
+	for _, p := range a.providers {
+		updates, err := p.Subscribe(ctx)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			for {
+				select {
+				case snapshot, ok := <-updates:
+					if !ok {
+						return
+					}
+					merged <- snapshot
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	var timer *time.Timer
+	var pending *Snapshot
+
+	for {
+		var fire <-chan time.Time
+		if timer != nil {
+			fire = timer.C
+		}
+
+		select {
+		case snapshot := <-merged:
+			s := snapshot
+			pending = &s
+			timer = time.NewTimer(a.debounce)
+
+		case <-fire:
+			if pending != nil {
+				if err := a.apply(applier, *pending); err != nil {
+					a.log.ERROR.Printf("failed applying configuration: %v", err)
+				}
+				pending = nil
+			}
+			timer = nil
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// apply diffs next against the last applied Snapshot and reconciles the
+// difference through applier.
+func (a *Aggregator) apply(applier Applier, next Snapshot) error {
+	if err := diffNamed(a.current.Meters, next.Meters, applier.ApplyMeter); err != nil {
+		return err
+	}
+	if err := diffNamed(a.current.Chargers, next.Chargers, applier.ApplyCharger); err != nil {
+		return err
+	}
+	if err := diffNamed(a.current.Vehicles, next.Vehicles, applier.ApplyVehicle); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(a.current.Loadpoints, next.Loadpoints) {
+		if err := applier.ApplyLoadpoints(next.Loadpoints); err != nil {
+			return err
+		}
+	}
+
+	if !reflect.DeepEqual(a.current.Tariffs, next.Tariffs) {
+		if err := applier.ApplyTariffs(next.Tariffs); err != nil {
+			return err
+		}
+	}
+
+	a.current = next
+
+	return nil
+}
+
+// diffNamed compares two Named slices by name and calls apply with
+// ActionAdd, ActionUpdate or ActionRemove for every entry that changed.
+func diffNamed(cur, next []Named, apply func(Action, Named, Named) error) error {
+	curByName := make(map[string]Named, len(cur))
+	for _, c := range cur {
+		curByName[c.Name] = c
+	}
+
+	nextByName := make(map[string]Named, len(next))
+	for _, n := range next {
+		nextByName[n.Name] = n
+
+		if c, ok := curByName[n.Name]; !ok {
+			if err := apply(ActionAdd, Named{}, n); err != nil {
+				return err
+			}
+		} else if !reflect.DeepEqual(c, n) {
+			if err := apply(ActionUpdate, c, n); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, c := range cur {
+		if _, ok := nextByName[c.Name]; !ok {
+			if err := apply(ActionRemove, c, Named{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+
+	"github.com/evcc-io/evcc/api"
 )
 
 type Config struct {
@@ -138,3 +140,49 @@ func DeleteConfig(class Class, id int) error {
 		return tx.Delete(Config{ID: id}).Error
 	})
 }
+
+// UpdateMeter replaces the live meter device registered under name with
+// dev, so a config.Aggregator update is reflected without a restart. It is
+// the update counterpart of AddMeter; unlike UpdateConfig it only touches
+// the in-memory registry, since file-configured devices never have a
+// database row to update in the first place.
+func UpdateMeter(name string, dev Device[api.Meter]) error {
+	if err := RemoveMeter(name); err != nil {
+		return err
+	}
+	return AddMeter(dev)
+}
+
+// RemoveMeter removes the live meter device registered under name. It is
+// the remove counterpart of AddMeter.
+func RemoveMeter(name string) error {
+	return DeleteDevice(name, Meter)
+}
+
+// UpdateCharger replaces the live charger device registered under name
+// with dev. See UpdateMeter.
+func UpdateCharger(name string, dev Device[api.Charger]) error {
+	if err := RemoveCharger(name); err != nil {
+		return err
+	}
+	return AddCharger(dev)
+}
+
+// RemoveCharger removes the live charger device registered under name.
+func RemoveCharger(name string) error {
+	return DeleteDevice(name, Charger)
+}
+
+// UpdateVehicle replaces the live vehicle device registered under name
+// with dev. See UpdateMeter.
+func UpdateVehicle(name string, dev Device[api.Vehicle]) error {
+	if err := RemoveVehicle(name); err != nil {
+		return err
+	}
+	return AddVehicle(dev)
+}
+
+// RemoveVehicle removes the live vehicle device registered under name.
+func RemoveVehicle(name string) error {
+	return DeleteDevice(name, Vehicle)
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"context"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// fileSnapshot is the on-disk shape of the subset of globalConfig the
+// FileProvider reloads at runtime.
+type fileSnapshot struct {
+	Meters     []Named          `mapstructure:"meters"`
+	Chargers   []Named          `mapstructure:"chargers"`
+	Vehicles   []Named          `mapstructure:"vehicles"`
+	Loadpoints []map[string]any `mapstructure:"loadpoints"`
+	Tariffs    map[string]any   `mapstructure:"tariffs"`
+}
+
+// FileProvider watches a YAML configuration file and publishes a fresh
+// Snapshot whenever it changes on disk.
+type FileProvider struct {
+	log  *util.Logger
+	file string
+}
+
+// NewFileProvider creates a Provider that watches file for changes using
+// fsnotify and re-reads it on every write/rename/create event.
+func NewFileProvider(file string) *FileProvider {
+	return &FileProvider{
+		log:  util.NewLogger("cfg-file"),
+		file: file,
+	}
+}
+
+func (p *FileProvider) Subscribe(ctx context.Context) (<-chan Snapshot, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(p.file); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	out := make(chan Snapshot)
+
+	snapshot, err := p.read()
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go func() {
+		defer watcher.Close()
+		defer close(out)
+
+		out <- snapshot
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+
+				snapshot, err := p.read()
+				if err != nil {
+					p.log.ERROR.Printf("failed reloading %s: %v", p.file, err)
+					continue
+				}
+
+				out <- snapshot
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				p.log.ERROR.Printf("watching %s: %v", p.file, err)
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// read parses the watched file into a Snapshot through the same
+// viper/mapstructure path cmd.loadConfigFile uses for the boot-time Seed.
+// A plain yaml.Unmarshal doesn't honor the `mapstructure:",remain"` tag
+// Named.Other relies on, so every reloaded device would come back with
+// an empty Other and an unchanged file would diff as an update of every
+// device at startup.
+func (p *FileProvider) read() (Snapshot, error) {
+	v := viper.New()
+	v.SetConfigFile(p.file)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return Snapshot{}, err
+	}
+
+	var fc fileSnapshot
+	if err := v.Unmarshal(&fc); err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{
+		Meters:     fc.Meters,
+		Chargers:   fc.Chargers,
+		Vehicles:   fc.Vehicles,
+		Loadpoints: fc.Loadpoints,
+		Tariffs:    fc.Tariffs,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gorm.io/gorm"
+)
+
+// snapshotSchemaVersion is bumped whenever the archive layout changes in a
+// way Restore needs to know about.
+const snapshotSchemaVersion = 1
+
+// snapshotHeader is the first entry of every snapshot archive.
+type snapshotHeader struct {
+	Version int `json:"version"`
+}
+
+// snapshotDevice is the serialized form of a Config row plus its details.
+type snapshotDevice struct {
+	ID      int            `json:"id"`
+	Class   Class          `json:"class"`
+	Type    string         `json:"type"`
+	Details map[string]any `json:"details"`
+}
+
+// Snapshot serializes every Config and ConfigDetail row into a versioned,
+// self-describing tar+json archive that Restore can replay on another
+// host, allowing operators to migrate an installation or roll back after
+// a bad edit.
+//
+// Scope: this only covers the device configuration (meters, chargers,
+// vehicles) stored in the Config/ConfigDetail tables. Loadpoint, site and
+// tariff settings live in server/db/settings' separate key/value store,
+// which is not part of this archive yet - restoring a snapshot does not
+// touch them. Extending the archive to cover settings is tracked as
+// follow-up work once that package exposes a way to enumerate its rows.
+func Snapshot(w io.Writer) error {
+	var devices []Config
+	if err := db.Preload("Details").Order("id").Find(&devices).Error; err != nil {
+		return err
+	}
+
+	snap := make([]snapshotDevice, 0, len(devices))
+	for _, d := range devices {
+		snap = append(snap, snapshotDevice{
+			ID:      d.ID,
+			Class:   d.Class,
+			Type:    d.Type,
+			Details: d.detailsAsMap(),
+		})
+	}
+
+	tw := tar.NewWriter(w)
+
+	if err := writeSnapshotEntry(tw, "version.json", snapshotHeader{Version: snapshotSchemaVersion}); err != nil {
+		return err
+	}
+
+	if err := writeSnapshotEntry(tw, "devices.json", snap); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+func writeSnapshotEntry(tw *tar.Writer, name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(b)), Mode: 0o644}); err != nil {
+		return err
+	}
+
+	_, err = tw.Write(b)
+	return err
+}
+
+// Restore replaces every Config and ConfigDetail row with the contents of
+// a Snapshot archive. It validates the schema version, re-runs
+// AutoMigrate and runs inside a single transaction so a malformed or
+// partial archive cannot leave the database half-migrated. As with
+// Snapshot, loadpoint/site/tariff settings are out of scope and are left
+// untouched.
+func Restore(r io.Reader) error {
+	header, devices, err := readSnapshot(r)
+	if err != nil {
+		return err
+	}
+
+	if header.Version == 0 {
+		return fmt.Errorf("restore: missing schema version")
+	}
+	if header.Version > snapshotSchemaVersion {
+		return fmt.Errorf("restore: snapshot schema version %d is newer than supported version %d", header.Version, snapshotSchemaVersion)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(new(ConfigDetail)).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(new(Config)).Error; err != nil {
+			return err
+		}
+
+		if err := tx.AutoMigrate(new(Config), new(ConfigDetail)); err != nil {
+			return err
+		}
+
+		for _, d := range devices {
+			device := Config{ID: d.ID, Class: d.Class, Type: d.Type}
+			if err := tx.Create(&device).Error; err != nil {
+				return err
+			}
+
+			details := device.mapAsDetails(d.Details)
+			if err := tx.Create(&details).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// readSnapshot parses a snapshot archive without touching the database,
+// so the schema version can be validated before a transaction is opened.
+func readSnapshot(r io.Reader) (snapshotHeader, []snapshotDevice, error) {
+	var header snapshotHeader
+	var devices []snapshotDevice
+
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return header, nil, err
+		}
+
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return header, nil, err
+		}
+
+		switch hdr.Name {
+		case "version.json":
+			if err := json.Unmarshal(b, &header); err != nil {
+				return header, nil, err
+			}
+		case "devices.json":
+			if err := json.Unmarshal(b, &devices); err != nil {
+				return header, nil, err
+			}
+		}
+	}
+
+	return header, devices, nil
+}
@@ -0,0 +1,95 @@
+package faultproxy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// HTTPMode selects which fault an HTTPTransport injects.
+type HTTPMode int
+
+const (
+	HTTPModeNone HTTPMode = iota
+	HTTPMode5xx
+	HTTPModeSlowBody
+	HTTPModeDNSFailure
+)
+
+// HTTPTransport wraps an http.RoundTripper (normally request.Transport)
+// and injects 5xx responses, slow response bodies, or DNS failures while
+// armed, so scenarios can exercise evcc's HTTP retry/backoff behaviour.
+type HTTPTransport struct {
+	next  http.RoundTripper
+	mode  atomic.Int32
+	delay time.Duration
+}
+
+// NewHTTPTransport wraps next, which is typically request.Transport so
+// the same logging/tracing wrapping evcc already installs stays in
+// effect.
+func NewHTTPTransport(next http.RoundTripper) *HTTPTransport {
+	return &HTTPTransport{next: next}
+}
+
+// Arm activates mode. delay is only used by HTTPModeSlowBody.
+func (t *HTTPTransport) Arm(mode HTTPMode, delay time.Duration) {
+	t.delay = delay
+	t.mode.Store(int32(mode))
+}
+
+// Disarm clears any active fault, restoring normal behaviour.
+func (t *HTTPTransport) Disarm() {
+	t.mode.Store(int32(HTTPModeNone))
+}
+
+func (t *HTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch HTTPMode(t.mode.Load()) {
+	case HTTPMode5xx:
+		return &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Status:     "502 Bad Gateway (faultproxy)",
+			Body:       http.NoBody,
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+
+	case HTTPModeDNSFailure:
+		return nil, errDNSFailure
+
+	case HTTPModeSlowBody:
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || resp.Body == nil {
+			return resp, err
+		}
+		resp.Body = newSlowReadCloser(resp.Body, t.delay)
+		return resp, nil
+
+	default:
+		return t.next.RoundTrip(req)
+	}
+}
+
+var errDNSFailure = errors.New("faultproxy: simulated DNS resolution failure")
+
+// slowReadCloser delays the first Read by delay, simulating a stalled
+// response body.
+type slowReadCloser struct {
+	io.ReadCloser
+	delay time.Duration
+	once  bool
+}
+
+func newSlowReadCloser(rc io.ReadCloser, delay time.Duration) io.ReadCloser {
+	return &slowReadCloser{ReadCloser: rc, delay: delay}
+}
+
+func (s *slowReadCloser) Read(p []byte) (int, error) {
+	if !s.once {
+		s.once = true
+		time.Sleep(s.delay)
+	}
+	return s.ReadCloser.Read(p)
+}
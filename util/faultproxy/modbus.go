@@ -0,0 +1,95 @@
+package faultproxy
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ModbusMode selects which fault a ModbusClient injects.
+type ModbusMode int
+
+const (
+	ModbusModeNone ModbusMode = iota
+	ModbusModeLatency
+	ModbusModeTimeout
+	ModbusModeCRCCorrupt
+)
+
+// modbusReader is the subset of modbus.Client used by evcc's register
+// reads, narrow enough that any real client implementation satisfies it.
+type modbusReader interface {
+	ReadHoldingRegisters(address, quantity uint16) ([]byte, error)
+	ReadInputRegisters(address, quantity uint16) ([]byte, error)
+}
+
+// ModbusClient wraps a modbusReader and injects latency, timeouts or
+// CRC-corrupted frames while armed. It is not connected to the
+// modbusProxy configuration or its ReadOnly mode.
+type ModbusClient struct {
+	next    modbusReader
+	mode    atomic.Int32
+	latency time.Duration
+}
+
+// NewModbusClient wraps next.
+func NewModbusClient(next modbusReader) *ModbusClient {
+	return &ModbusClient{next: next}
+}
+
+// Arm activates mode. latency is only used by ModbusModeLatency.
+func (c *ModbusClient) Arm(mode ModbusMode, latency time.Duration) {
+	c.latency = latency
+	c.mode.Store(int32(mode))
+}
+
+// Disarm clears any active fault.
+func (c *ModbusClient) Disarm() {
+	c.mode.Store(int32(ModbusModeNone))
+}
+
+var errModbusTimeout = errors.New("faultproxy: simulated modbus timeout")
+
+func (c *ModbusClient) ReadHoldingRegisters(address, quantity uint16) ([]byte, error) {
+	if b, err, handled := c.inject(); handled {
+		return b, err
+	}
+	return c.corrupt(c.next.ReadHoldingRegisters(address, quantity))
+}
+
+func (c *ModbusClient) ReadInputRegisters(address, quantity uint16) ([]byte, error) {
+	if b, err, handled := c.inject(); handled {
+		return b, err
+	}
+	return c.corrupt(c.next.ReadInputRegisters(address, quantity))
+}
+
+// inject applies latency/timeout faults that short-circuit the call
+// entirely. handled is false when the call should proceed to the real
+// client (possibly with its response corrupted afterwards).
+func (c *ModbusClient) inject() ([]byte, error, bool) {
+	switch ModbusMode(c.mode.Load()) {
+	case ModbusModeLatency:
+		time.Sleep(c.latency)
+		return nil, nil, false
+	case ModbusModeTimeout:
+		return nil, errModbusTimeout, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// corrupt flips the last byte of an otherwise successful response when
+// ModbusModeCRCCorrupt is armed, simulating a CRC-failing frame on the
+// wire.
+func (c *ModbusClient) corrupt(b []byte, err error) ([]byte, error) {
+	if err != nil || ModbusMode(c.mode.Load()) != ModbusModeCRCCorrupt || len(b) == 0 {
+		return b, err
+	}
+
+	corrupted := make([]byte, len(b))
+	copy(corrupted, b)
+	corrupted[len(corrupted)-1] ^= 0xff
+
+	return corrupted, nil
+}
@@ -0,0 +1,132 @@
+package faultproxy
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTTFaults interposes on a paho.Client's Publish calls to drop, delay or
+// duplicate messages, and can simulate the broker going away for a fixed
+// duration. The DropPublishes/DelayPublishes/DuplicatePublishes/
+// DisconnectBroker/Reset setters run from the scenario runner
+// goroutine while Publish runs from whatever goroutine is publishing, so
+// every field mutable after construction is guarded by mu.
+type MQTTFaults struct {
+	mu sync.Mutex
+
+	drop     bool
+	delay    time.Duration
+	dupe     bool
+	dropRate float64
+	until    time.Time
+}
+
+// NewMQTTFaults creates an idle fault set; call the DropPublishes,
+// DelayPublishes, DuplicatePublishes or DisconnectBroker setters to arm
+// specific faults.
+func NewMQTTFaults() *MQTTFaults {
+	return &MQTTFaults{}
+}
+
+// DropPublishes drops every publish with the given probability (0..1)
+// while armed.
+func (f *MQTTFaults) DropPublishes(rate float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.drop = rate > 0
+	f.dropRate = rate
+}
+
+// DelayPublishes delays every publish by d while armed.
+func (f *MQTTFaults) DelayPublishes(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delay = d
+}
+
+// DuplicatePublishes re-sends every publish a second time while armed.
+func (f *MQTTFaults) DuplicatePublishes(enable bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dupe = enable
+}
+
+// DisconnectBroker makes the broker appear unreachable for d.
+func (f *MQTTFaults) DisconnectBroker(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.until = time.Now().Add(d)
+}
+
+// Reset clears every armed fault.
+func (f *MQTTFaults) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.drop = false
+	f.delay = 0
+	f.dupe = false
+	f.dropRate = 0
+	f.until = time.Time{}
+}
+
+// snapshot returns a copy of the currently armed faults under mu, so
+// Publish can apply them without holding the lock for the duration of a
+// simulated delay or the real publish call.
+func (f *MQTTFaults) snapshot() (drop bool, delay time.Duration, dupe bool, dropRate float64, brokerDown bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.drop, f.delay, f.dupe, f.dropRate, time.Now().Before(f.until)
+}
+
+// Publish wraps client.Publish, applying every armed fault before handing
+// off to the real client. It is meant to be called from the same call
+// sites provider/mqtt uses today, with client swapped for a faulted
+// client during a functional test run.
+func (f *MQTTFaults) Publish(client paho.Client, topic string, qos byte, retained bool, payload interface{}) paho.Token {
+	drop, delay, dupe, dropRate, brokerDown := f.snapshot()
+
+	if brokerDown {
+		return &disconnectedToken{}
+	}
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+
+	if drop && rand.Float64() < dropRate {
+		return &noopToken{}
+	}
+
+	token := client.Publish(topic, qos, retained, payload)
+
+	if dupe {
+		client.Publish(topic, qos, retained, payload)
+	}
+
+	return token
+}
+
+// noopToken reports immediate, faultless completion without actually
+// publishing, used to simulate a dropped message.
+type noopToken struct{}
+
+func (noopToken) Wait() bool                     { return true }
+func (noopToken) WaitTimeout(time.Duration) bool { return true }
+func (noopToken) Done() <-chan struct{}          { ch := make(chan struct{}); close(ch); return ch }
+func (noopToken) Error() error                   { return nil }
+
+// disconnectedToken reports the broker as unreachable, used to simulate
+// the broker being down.
+type disconnectedToken struct{}
+
+func (disconnectedToken) Wait() bool                     { return true }
+func (disconnectedToken) WaitTimeout(time.Duration) bool { return true }
+func (disconnectedToken) Done() <-chan struct{}          { ch := make(chan struct{}); close(ch); return ch }
+func (disconnectedToken) Error() error                   { return paho.ErrNotConnected }
+
+var _ paho.Token = noopToken{}
+var _ paho.Token = disconnectedToken{}
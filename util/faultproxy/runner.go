@@ -0,0 +1,158 @@
+package faultproxy
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Invariant is checked after every scenario tick. It should return a
+// non-nil error describing the violation, or nil if the invariant holds.
+type Invariant func() error
+
+// Runner drives a Scenario's steps against a running instance at their
+// scheduled offsets and checks every registered invariant after each step
+// and once more after the scenario completes, so violations that only
+// show up during recovery are caught too.
+type Runner struct {
+	log        *util.Logger
+	scenario   Scenario
+	invariants []Invariant
+	apply      map[string]func(Step) error
+}
+
+// NewRunner creates a Runner for scenario. apply maps a step's Target
+// prefix (e.g. "mqtt", "modbus", "http") to the function that arms or
+// disarms the corresponding fault.
+func NewRunner(scenario Scenario, apply map[string]func(Step) error) *Runner {
+	return &Runner{
+		log:      util.NewLogger("functional"),
+		scenario: scenario,
+		apply:    apply,
+	}
+}
+
+// AddInvariant registers an invariant checked after every step.
+func (r *Runner) AddInvariant(name string, check Invariant) {
+	r.invariants = append(r.invariants, func() error {
+		if err := check(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// Run executes every step at its scheduled offset from start and
+// verifies all invariants after each one, returning the first violation
+// encountered.
+func (r *Runner) Run() error {
+	start := time.Now()
+
+	for _, step := range r.scenario.Steps {
+		if wait := time.Until(start.Add(time.Duration(step.At))); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		handler, ok := r.apply[target(step.Target)]
+		if !ok {
+			return fmt.Errorf("scenario %s: no fault handler for target %q", r.scenario.Name, step.Target)
+		}
+
+		r.log.INFO.Printf("applying fault %s on %s", step.Fault, step.Target)
+
+		if err := handler(step); err != nil {
+			return fmt.Errorf("scenario %s: applying %s on %s: %w", r.scenario.Name, step.Fault, step.Target, err)
+		}
+
+		if err := r.checkInvariants(); err != nil {
+			return err
+		}
+
+		if step.Duration > 0 {
+			time.Sleep(time.Duration(step.Duration))
+
+			if err := r.checkInvariants(); err != nil {
+				return err
+			}
+
+			// the step's Duration is up: clear its fault as a "restore" step would
+			if err := handler(Step{Target: step.Target, Fault: "restore"}); err != nil {
+				return fmt.Errorf("scenario %s: restoring %s: %w", r.scenario.Name, step.Target, err)
+			}
+		}
+	}
+
+	return r.checkInvariants()
+}
+
+func (r *Runner) checkInvariants() error {
+	for _, check := range r.invariants {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// target returns the transport prefix of a "transport[:device]" target
+// string, e.g. "modbus" for "modbus:chargerX".
+func target(s string) string {
+	for i, c := range s {
+		if c == ':' {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// MaxCurrentInvariant asserts the charger was never commanded above max.
+// last should be updated by the caller's MaxCurrent wrapper on every call.
+func MaxCurrentInvariant(max int64, last *int64) Invariant {
+	return func() error {
+		if *last > max {
+			return fmt.Errorf("charger commanded %dA, exceeds configured max %dA", *last, max)
+		}
+		return nil
+	}
+}
+
+// SustainedFailureInvariant asserts counter - a consecutive-failure count
+// a device under test increments on every failed attempt at some
+// transport call and resets to 0 on success - never exceeds threshold.
+// This is what catches a scenario's mqtt/http faults having no real
+// effect: if the device never calls through the faulted transport,
+// counter never moves and the invariant passes vacuously; if it does and
+// the fault's reconnect/backoff logic never recovers, counter keeps
+// climbing and trips the threshold instead of a single expected blip.
+func SustainedFailureInvariant(name string, threshold int32, counter *atomic.Int32) Invariant {
+	return func() error {
+		if n := counter.Load(); n > threshold {
+			return fmt.Errorf("%s: %d consecutive failures, exceeds threshold %d", name, n, threshold)
+		}
+		return nil
+	}
+}
+
+// EnabledWhileDisabledInvariant asserts a disabled charger was never
+// observed enabled.
+func EnabledWhileDisabledInvariant(charger api.Charger, disabled func() bool) Invariant {
+	return func() error {
+		if !disabled() {
+			return nil
+		}
+
+		enabled, err := charger.Enabled()
+		if err != nil {
+			return nil //nolint:nilerr // transport errors are not invariant violations
+		}
+
+		if enabled {
+			return fmt.Errorf("charger is enabled while it should be disabled")
+		}
+
+		return nil
+	}
+}
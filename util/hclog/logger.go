@@ -0,0 +1,168 @@
+// Package hclog provides a structured, per-device contextual logger built
+// on hashicorp/go-hclog. It sits alongside evcc's existing string-prefixed
+// util.Logger: call sites that only need plain text keep using
+// util.NewLogger unchanged, while sites that want key/value fields
+// (device=wallbox1, class=charger, loadpoint=2, phase=3, soc=54) that
+// downstream sinks (file, JSON, Loki, InfluxDB tags) can correlate per
+// device and per loadpoint tick use this package instead.
+package hclog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	gohclog "github.com/hashicorp/go-hclog"
+)
+
+// Format selects the wire format every Logger created after it is set
+// uses. It is configured once at startup from the `logging:` config
+// section.
+var Format = FormatText
+
+// Levels maps a Logger's name (the string passed to New) to the minimum
+// level it emits, e.g. {"charger": "warn"} to quiet routine charger chatter
+// while leaving everything else at the default. It is configured once at
+// startup from the `logging:` config section's per-subsystem levels;
+// unset or unparsable entries fall back to Info.
+var Levels map[string]string
+
+type OutputFormat int
+
+const (
+	FormatText OutputFormat = iota
+	FormatJSON
+)
+
+// Fields are the structured key/value pairs attached to every line a
+// Logger emits.
+type Fields map[string]any
+
+// Logger mirrors the level fields (INFO, WARN, ERROR, DEBUG) evcc's
+// util.Logger already exposes via dot access (log.INFO.Printf(...)), so
+// call sites read the same way, but every line additionally carries the
+// fields the Logger was created or extended with.
+type Logger struct {
+	INFO  Level
+	WARN  Level
+	ERROR Level
+	DEBUG Level
+
+	base gohclog.Logger
+}
+
+// Level is a single severity's formatted-logging surface. sampler is nil
+// for WARN/ERROR; INFO/DEBUG share the process-wide sampler configured by
+// SetSampling, if any.
+type Level struct {
+	base    gohclog.Logger
+	level   gohclog.Level
+	sampler *sampler
+}
+
+func (l Level) Printf(format string, v ...any) {
+	l.log(fmt.Sprintf(format, v...))
+}
+
+func (l Level) Println(v ...any) {
+	l.log(fmt.Sprint(v...))
+}
+
+func (l Level) Print(v ...any) {
+	l.log(fmt.Sprint(v...))
+}
+
+func (l Level) log(msg string) {
+	if l.sampler != nil && !l.sampler.allow(msg) {
+		return
+	}
+	l.base.Log(l.level, msg)
+}
+
+// New creates a Logger for name carrying fields on every line, at the
+// level Levels configures for name (Info if unset).
+func New(name string, fields Fields) *Logger {
+	base := gohclog.New(&gohclog.LoggerOptions{
+		Name:       name,
+		Level:      levelFor(name),
+		Output:     os.Stderr,
+		JSONFormat: Format == FormatJSON,
+	})
+
+	return newLogger(base).With(fields)
+}
+
+// levelFor resolves name's configured level from Levels, falling back to
+// Info when unset or unparsable.
+func levelFor(name string) gohclog.Level {
+	s, ok := Levels[name]
+	if !ok {
+		return gohclog.Info
+	}
+
+	if lvl := gohclog.LevelFromString(s); lvl != gohclog.NoLevel {
+		return lvl
+	}
+
+	return gohclog.Info
+}
+
+// With returns a copy of l with additional fields merged in, e.g. a
+// loadpoint logger gaining a `vehicle=` field once one is assigned.
+func (l *Logger) With(fields Fields) *Logger {
+	kv := make([]interface{}, 0, 2*len(fields))
+	for k, v := range fields {
+		kv = append(kv, k, v)
+	}
+
+	return newLogger(l.base.With(kv...))
+}
+
+func newLogger(base gohclog.Logger) *Logger {
+	return &Logger{
+		base:  base,
+		INFO:  Level{base, gohclog.Info, globalSampler},
+		WARN:  Level{base, gohclog.Warn, nil},
+		ERROR: Level{base, gohclog.Error, nil},
+		DEBUG: Level{base, gohclog.Debug, globalSampler},
+	}
+}
+
+// globalSampler is nil (no sampling) until SetSampling configures an
+// every-Nth-line sampler for every Logger created afterwards.
+var globalSampler *sampler
+
+// SetSampling configures every subsequently created Logger to only emit
+// every nth repeated INFO/DEBUG line, keyed by exact message text, so a
+// noisy repeated line (e.g. a transport retrying once a second) doesn't
+// drown out everything else without being silenced entirely. n <= 1
+// disables sampling. WARN/ERROR are never sampled.
+func SetSampling(n int) {
+	if n <= 1 {
+		globalSampler = nil
+		return
+	}
+	globalSampler = newSampler(n)
+}
+
+// sampler allows the first occurrence of every nth identical message
+// through and drops the rest.
+type sampler struct {
+	every int
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newSampler(every int) *sampler {
+	return &sampler{every: every, counts: make(map[string]int)}
+}
+
+func (s *sampler) allow(msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[msg]++
+
+	return s.counts[msg]%s.every == 1
+}